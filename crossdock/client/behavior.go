@@ -20,7 +20,10 @@
 
 package client
 
-import "fmt"
+import (
+	"fmt"
+	"sync"
+)
 
 // Status represents the result of running a behavior.
 type Status string
@@ -101,6 +104,12 @@ type BehaviorTester struct {
 	Failed  bool
 	Skipped bool
 	Entries []interface{}
+
+	// mu guards Failed, Skipped, Entries and sealed against concurrent
+	// behaviors (e.g. one abandoned after a Runner timeout) recording
+	// entries at the same time as a caller reads the results.
+	mu     sync.Mutex
+	sealed bool
 }
 
 // NewBehavior provides a new Behavior that may be passed into a test to record
@@ -109,8 +118,16 @@ func (bt *BehaviorTester) NewBehavior(builder EntryBuilder) Behavior {
 	return behavior{Params: bt.Params, Tester: bt, Builder: builder}
 }
 
-// putEntry records a new entry with this BehaviorTester.
+// putEntry records a new entry with this BehaviorTester, unless it has
+// been sealed.
 func (bt *BehaviorTester) putEntry(entry interface{}, status Status) {
+	bt.mu.Lock()
+	defer bt.mu.Unlock()
+
+	if bt.sealed {
+		return
+	}
+
 	switch status {
 	case Failed:
 		bt.Failed = true
@@ -122,6 +139,26 @@ func (bt *BehaviorTester) putEntry(entry interface{}, status Status) {
 	bt.Entries = append(bt.Entries, entry)
 }
 
+// seal stops bt from recording any further entries. It is used to cut off
+// a behavior that is still running after it has already been reported
+// (e.g. timed out), so it cannot keep mutating state a caller is reading.
+func (bt *BehaviorTester) seal() {
+	bt.mu.Lock()
+	bt.sealed = true
+	bt.mu.Unlock()
+}
+
+// Snapshot returns a copy of bt's current Failed, Skipped and Entries
+// fields, taken atomically with respect to putEntry and seal. Callers
+// that may run concurrently with a behavior still in flight (as Runner's
+// behaviors can be) should use Snapshot instead of reading the fields
+// directly.
+func (bt *BehaviorTester) Snapshot() (failed, skipped bool, entries []interface{}) {
+	bt.mu.Lock()
+	defer bt.mu.Unlock()
+	return bt.Failed, bt.Skipped, append([]interface{}(nil), bt.Entries...)
+}
+
 //////////////////////////////////////////////////////////////////////////////
 
 type behavior struct {
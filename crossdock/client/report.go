@@ -0,0 +1,123 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Report renders a Runner's Results as JSON or JUnit XML for consumption
+// by CI systems.
+type Report struct {
+	Results Results
+}
+
+// jsonResult is the JSON-serializable view of a single Result.
+type jsonResult struct {
+	Name    string        `json:"name"`
+	Failed  bool          `json:"failed"`
+	Skipped bool          `json:"skipped"`
+	Entries []interface{} `json:"entries"`
+}
+
+// WriteJSON serializes the report as a JSON array of behavior results,
+// one per registered behavior, to w.
+func (r Report) WriteJSON(w io.Writer) error {
+	results := make([]jsonResult, len(r.Results))
+	for i, res := range r.Results {
+		failed, skipped, entries := res.Tester.Snapshot()
+		results[i] = jsonResult{
+			Name:    res.Name,
+			Failed:  failed,
+			Skipped: skipped,
+			Entries: entries,
+		}
+	}
+	return json.NewEncoder(w).Encode(results)
+}
+
+// junitTestSuite is the subset of the JUnit XML schema understood by
+// Jenkins and GitHub Actions test-result parsers.
+type junitTestSuite struct {
+	XMLName   xml.Name        `xml:"testsuite"`
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// WriteJUnitXML serializes the report as a single JUnit XML <testsuite>
+// named suiteName, with one <testcase> per behavior, to w.
+func (r Report) WriteJUnitXML(w io.Writer, suiteName string) error {
+	suite := junitTestSuite{Name: suiteName}
+	for _, res := range r.Results {
+		failed, skipped, entries := res.Tester.Snapshot()
+		tc := junitTestCase{Name: res.Name, ClassName: suiteName}
+		suite.Tests++
+		switch {
+		case failed:
+			suite.Failures++
+			tc.Failure = &junitMessage{Message: "behavior failed", Body: entriesString(entries)}
+		case skipped:
+			suite.Skipped++
+			tc.Skipped = &junitMessage{Message: "behavior skipped", Body: entriesString(entries)}
+		}
+		suite.TestCases = append(suite.TestCases, tc)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(suite); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// entriesString renders a behavior's entries as a human-readable, newline
+// separated body for a JUnit failure/skipped element.
+func entriesString(entries []interface{}) string {
+	lines := make([]string, len(entries))
+	for i, entry := range entries {
+		lines[i] = fmt.Sprintf("%+v", entry)
+	}
+	return strings.Join(lines, "\n")
+}
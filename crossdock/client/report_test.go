@@ -0,0 +1,72 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newReport() Report {
+	passing := &BehaviorTester{}
+	passing.putEntry(BasicEntryBuilder.Pass("ok"), Passed)
+
+	failing := &BehaviorTester{}
+	failing.putEntry(BasicEntryBuilder.Fail("boom"), Failed)
+
+	return Report{Results: Results{
+		{Name: "passing", Tester: passing},
+		{Name: "failing", Tester: failing},
+	}}
+}
+
+func TestReportWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, newReport().WriteJSON(&buf))
+
+	var results []jsonResult
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &results))
+	require.Len(t, results, 2)
+	assert.Equal(t, "passing", results[0].Name)
+	assert.False(t, results[0].Failed)
+	assert.Equal(t, "failing", results[1].Name)
+	assert.True(t, results[1].Failed)
+}
+
+func TestReportWriteJUnitXML(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, newReport().WriteJUnitXML(&buf, "crossdock"))
+
+	var suite junitTestSuite
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &suite))
+	assert.Equal(t, "crossdock", suite.Name)
+	assert.Equal(t, 2, suite.Tests)
+	assert.Equal(t, 1, suite.Failures)
+	require.Len(t, suite.TestCases, 2)
+	assert.Nil(t, suite.TestCases[0].Failure)
+	require.NotNil(t, suite.TestCases[1].Failure)
+	assert.Equal(t, "behavior failed", suite.TestCases[1].Failure.Message)
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import "time"
+
+// RichEntry is an entry produced by RichEntryBuilder. Unlike BasicEntry, it
+// carries enough information for a CI reporter to render a timeline and to
+// explain a failure without re-running the behavior.
+type RichEntry struct {
+	Status    Status                 `json:"status"`
+	Output    string                 `json:"output"`
+	Timestamp time.Time              `json:"timestamp"`
+	Duration  time.Duration          `json:"duration"`
+	Context   map[string]interface{} `json:"context,omitempty"`
+}
+
+// RichEntryBuilder is an EntryBuilder that builds RichEntry objects,
+// stamping each with the time elapsed since the builder was created and
+// whatever key/value context has been attached with With.
+type RichEntryBuilder struct {
+	start   time.Time
+	now     func() time.Time
+	context map[string]interface{}
+}
+
+// NewRichEntryBuilder builds a RichEntryBuilder whose entries are
+// timestamped and measured relative to the moment it is created. Use With
+// to attach structured context that should appear on every entry built
+// afterwards.
+func NewRichEntryBuilder() *RichEntryBuilder {
+	return &RichEntryBuilder{start: time.Now(), now: time.Now}
+}
+
+// With returns a copy of the builder carrying additional key/value
+// context that will be included on every entry it builds.
+func (b *RichEntryBuilder) With(key string, value interface{}) *RichEntryBuilder {
+	context := make(map[string]interface{}, len(b.context)+1)
+	for k, v := range b.context {
+		context[k] = v
+	}
+	context[key] = value
+	return &RichEntryBuilder{start: b.start, now: b.now, context: context}
+}
+
+func (b *RichEntryBuilder) build(status Status, output string) interface{} {
+	now := b.now()
+	return RichEntry{
+		Status:    status,
+		Output:    output,
+		Timestamp: now,
+		Duration:  now.Sub(b.start),
+		Context:   b.context,
+	}
+}
+
+// Skip for RichEntryBuilder.
+func (b *RichEntryBuilder) Skip(reason string) interface{} { return b.build(Skipped, reason) }
+
+// Fail for RichEntryBuilder.
+func (b *RichEntryBuilder) Fail(message string) interface{} { return b.build(Failed, message) }
+
+// Pass for RichEntryBuilder.
+func (b *RichEntryBuilder) Pass(output string) interface{} { return b.build(Passed, output) }
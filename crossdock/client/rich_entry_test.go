@@ -0,0 +1,59 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunnerWithRichEntryBuilder(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := start
+	builder := &RichEntryBuilder{start: start, now: func() time.Time { return now }}
+	builder = builder.With("transport", "http")
+
+	now = start.Add(5 * time.Millisecond)
+
+	runner := &Runner{
+		Params:       mapParams{},
+		EntryBuilder: builder,
+		Behaviors: []BehaviorSpec{
+			{Name: "fails", Run: func(b Behavior) { b.Fail("nope") }},
+		},
+	}
+
+	results := runner.Run()
+	require.Len(t, results, 1)
+
+	_, _, entries := results[0].Tester.Snapshot()
+	require.Len(t, entries, 1)
+	assert.Equal(t, RichEntry{
+		Status:    Failed,
+		Output:    "nope",
+		Timestamp: now,
+		Duration:  5 * time.Millisecond,
+		Context:   map[string]interface{}{"transport": "http"},
+	}, entries[0])
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"sync"
+	"time"
+)
+
+// BehaviorFunc is a registered behavior implementation. It is handed a
+// Behavior through which it reports Pass/Fail/Skip entries.
+type BehaviorFunc func(Behavior)
+
+// BehaviorSpec describes a single behavior registered with a Runner: its
+// name, its implementation, and the parameters it understands. Required
+// and Optional are informational only: the Runner does not check them,
+// and they are not included in a Result or a Report.
+type BehaviorSpec struct {
+	Name     string
+	Run      BehaviorFunc
+	Required []string
+	Optional []string
+}
+
+// Result is the outcome of running a single BehaviorSpec.
+type Result struct {
+	Name   string
+	Tester *BehaviorTester
+}
+
+// Results is the outcome of a full Runner run.
+type Results []Result
+
+// Failed reports whether any Result failed.
+func (rs Results) Failed() bool {
+	for _, r := range rs {
+		if failed, _, _ := r.Tester.Snapshot(); failed {
+			return true
+		}
+	}
+	return false
+}
+
+// ExitCode returns the process exit code that should be used to report
+// rs: 1 if any behavior failed, 0 otherwise.
+func (rs Results) ExitCode() int {
+	if rs.Failed() {
+		return 1
+	}
+	return 0
+}
+
+// Runner executes a set of registered behaviors and aggregates their
+// BehaviorTester results, optionally bounding how many behaviors run at
+// once and how long any one of them is allowed to take.
+type Runner struct {
+	// Behaviors is the set of behaviors to execute.
+	Behaviors []BehaviorSpec
+
+	// Params provides parameter values to every behavior.
+	Params Params
+
+	// EntryBuilder builds the entries recorded by each behavior. Defaults
+	// to BasicEntryBuilder.
+	EntryBuilder EntryBuilder
+
+	// Concurrency bounds how many behaviors may run at once. Zero or
+	// negative means unbounded.
+	Concurrency int
+
+	// Timeout bounds how long a single behavior may run before it is
+	// recorded as skipped with reason "timeout". Zero means unbounded.
+	Timeout time.Duration
+}
+
+// Run executes every registered behavior and returns their aggregated
+// Results, in the same order as r.Behaviors.
+func (r *Runner) Run() Results {
+	builder := r.EntryBuilder
+	if builder == nil {
+		builder = BasicEntryBuilder
+	}
+
+	results := make(Results, len(r.Behaviors))
+
+	var sem chan struct{}
+	if r.Concurrency > 0 {
+		sem = make(chan struct{}, r.Concurrency)
+	}
+
+	var wg sync.WaitGroup
+	for i, spec := range r.Behaviors {
+		wg.Add(1)
+		go func(i int, spec BehaviorSpec) {
+			defer wg.Done()
+			if sem != nil {
+				sem <- struct{}{}
+				defer func() { <-sem }()
+			}
+			results[i] = Result{Name: spec.Name, Tester: r.runOne(spec, builder)}
+		}(i, spec)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// runOne runs a single behavior to completion, or records it as skipped
+// with reason "timeout" if it does not finish within r.Timeout.
+func (r *Runner) runOne(spec BehaviorSpec, builder EntryBuilder) *BehaviorTester {
+	tester := &BehaviorTester{Params: r.Params}
+
+	if r.Timeout <= 0 {
+		spec.Run(tester.NewBehavior(builder))
+		return tester
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		spec.Run(tester.NewBehavior(builder))
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(r.Timeout):
+		// The goroutine above may still be running (there is no way to
+		// cancel an arbitrary BehaviorFunc), so seal the tester right
+		// after recording the timeout: any entries it tries to record
+		// afterwards are dropped instead of racing with this result.
+		tester.NewBehavior(builder).Skip("timeout")
+		tester.seal()
+	}
+	return tester
+}
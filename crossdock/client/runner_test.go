@@ -0,0 +1,134 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mapParams map[string]string
+
+func (p mapParams) Param(name string) string { return p[name] }
+
+func TestRunnerAggregatesResults(t *testing.T) {
+	runner := &Runner{
+		Params: mapParams{},
+		Behaviors: []BehaviorSpec{
+			{Name: "passes", Run: func(b Behavior) { b.Pass("ok") }},
+			{Name: "fails", Run: func(b Behavior) { b.Fail("nope") }},
+			{Name: "skips", Run: func(b Behavior) { b.Skip("not applicable") }},
+		},
+	}
+
+	results := runner.Run()
+	testers := map[string]*BehaviorTester{}
+	for _, r := range results {
+		testers[r.Name] = r.Tester
+	}
+
+	assert.False(t, testers["passes"].Failed || testers["passes"].Skipped)
+	assert.True(t, testers["fails"].Failed)
+	assert.True(t, testers["skips"].Skipped)
+	assert.True(t, results.Failed())
+	assert.Equal(t, 1, results.ExitCode())
+}
+
+func TestRunnerTimeout(t *testing.T) {
+	runner := &Runner{
+		Params:  mapParams{},
+		Timeout: 10 * time.Millisecond,
+		Behaviors: []BehaviorSpec{
+			{Name: "slow", Run: func(b Behavior) { time.Sleep(100 * time.Millisecond) }},
+		},
+	}
+
+	results := runner.Run()
+	_, skipped, entries := results[0].Tester.Snapshot()
+	assert.True(t, skipped)
+	assert.Equal(t, BasicEntry{Status: Skipped, Output: "timeout"}, entries[0])
+}
+
+// TestRunnerTimeoutSealsAbandonedBehavior reproduces the scenario a
+// behavior that outlives its timeout used to race on: it keeps calling
+// into its Behavior well after runOne has already reported it as timed
+// out, while the caller reads the same BehaviorTester concurrently (e.g.
+// building a Report). Run under -race, this must not trip the detector,
+// and the entries recorded after the timeout must not appear in the
+// snapshot already handed to the caller.
+func TestRunnerTimeoutSealsAbandonedBehavior(t *testing.T) {
+	released := make(chan struct{})
+	runner := &Runner{
+		Params:  mapParams{},
+		Timeout: 5 * time.Millisecond,
+		Behaviors: []BehaviorSpec{
+			{Name: "slow", Run: func(b Behavior) {
+				<-released
+				for i := 0; i < 100; i++ {
+					b.Pass("late")
+				}
+			}},
+		},
+	}
+
+	results := runner.Run()
+	_, skipped, entriesAtReturn := results[0].Tester.Snapshot()
+	assert.True(t, skipped)
+	assert.Len(t, entriesAtReturn, 1)
+
+	close(released)
+	time.Sleep(10 * time.Millisecond)
+
+	_, _, entriesAfter := results[0].Tester.Snapshot()
+	assert.Equal(t, entriesAtReturn, entriesAfter)
+}
+
+func TestRunnerConcurrencyLimit(t *testing.T) {
+	const limit = 2
+	var active, maxActive int32
+
+	behaviors := make([]BehaviorSpec, 6)
+	for i := range behaviors {
+		behaviors[i] = BehaviorSpec{
+			Name: "b",
+			Run: func(b Behavior) {
+				n := atomic.AddInt32(&active, 1)
+				for {
+					max := atomic.LoadInt32(&maxActive)
+					if n <= max || atomic.CompareAndSwapInt32(&maxActive, max, n) {
+						break
+					}
+				}
+				time.Sleep(5 * time.Millisecond)
+				atomic.AddInt32(&active, -1)
+				b.Pass("ok")
+			},
+		}
+	}
+
+	runner := &Runner{Params: mapParams{}, Concurrency: limit, Behaviors: behaviors}
+	runner.Run()
+
+	assert.LessOrEqual(t, int(atomic.LoadInt32(&maxActive)), limit)
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package transport
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	"golang.org/x/net/context"
+)
+
+// ChainInterceptors combines a series of Interceptors into a single
+// Interceptor that calls them in the order given, with the first
+// Interceptor as the outermost: it sees the context, request, and
+// ResponseWriter before any of the others do, and it is the last to see
+// the returned error. Context values set and ResponseWriter wrapping done
+// by an outer Interceptor are visible to every Interceptor nested inside
+// it, all the way down to the Handler.
+//
+// A panic raised by any Interceptor or by the Handler itself is recovered
+// at the point it occurred and converted into an error, so a single
+// misbehaving link does not take down the rest of the chain. The
+// recovered stack trace is attached to the context and can be retrieved
+// with StackTrace.
+func ChainInterceptors(interceptors ...Interceptor) Interceptor {
+	return chain(interceptors)
+}
+
+type chain []Interceptor
+
+func (c chain) Handle(ctx context.Context, req *Request, resw ResponseWriter, h Handler) error {
+	ctx = context.WithValue(ctx, stackTraceKey{}, new(stackTraceBox))
+	return c.dispatch(0, ctx, req, resw, h)
+}
+
+// dispatch invokes c[i], wiring its "next" Handler to recurse into
+// c[i+1], or into the terminal Handler h once the chain is exhausted.
+// Each level recovers its own panics so that a panic at depth i is
+// reported as an error to c[i-1] rather than unwinding the whole chain.
+func (c chain) dispatch(i int, ctx context.Context, req *Request, resw ResponseWriter, h Handler) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if box, ok := ctx.Value(stackTraceKey{}).(*stackTraceBox); ok {
+				box.stack = debug.Stack()
+			}
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	if i == len(c) {
+		return h.Handle(ctx, req, resw)
+	}
+
+	next := chainHandler{chain: c, i: i + 1, terminal: h}
+	return c[i].Handle(ctx, req, resw, next)
+}
+
+// chainHandler adapts the remainder of a chain, starting at i, into a
+// Handler so it can be passed to an Interceptor as its "next" handler.
+type chainHandler struct {
+	chain    chain
+	i        int
+	terminal Handler
+}
+
+func (n chainHandler) Handle(ctx context.Context, req *Request, resw ResponseWriter) error {
+	return n.chain.dispatch(n.i, ctx, req, resw, n.terminal)
+}
+
+// stackTraceKey is the well-known context key under which ChainInterceptors
+// stashes the stack trace of a recovered panic, if any.
+type stackTraceKey struct{}
+
+// stackTraceBox is installed once per request so that the goroutine
+// running deep inside the chain can record a stack trace that is visible
+// to every Interceptor up the chain through the same context value.
+type stackTraceBox struct {
+	stack []byte
+}
+
+// StackTrace returns the stack trace captured by ChainInterceptors when it
+// recovered a panic while handling ctx's request. It returns false if no
+// panic was recovered, or if ctx was not produced by a chained Interceptor.
+func StackTrace(ctx context.Context) ([]byte, bool) {
+	box, ok := ctx.Value(stackTraceKey{}).(*stackTraceBox)
+	if !ok || box.stack == nil {
+		return nil, false
+	}
+	return box.stack, true
+}
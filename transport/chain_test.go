@@ -0,0 +1,101 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package transport
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+type fakeResponseWriter struct{}
+
+func (fakeResponseWriter) Write(p []byte) (int, error) { return len(p), nil }
+func (fakeResponseWriter) AddHeaders(Headers)           {}
+func (fakeResponseWriter) SetApplicationError()         {}
+
+type orderInterceptor struct {
+	name  string
+	order *[]string
+}
+
+func (o orderInterceptor) Handle(ctx context.Context, req *Request, resw ResponseWriter, h Handler) error {
+	*o.order = append(*o.order, "before:"+o.name)
+	err := h.Handle(ctx, req, resw)
+	*o.order = append(*o.order, "after:"+o.name)
+	return err
+}
+
+type funcHandler func(context.Context, *Request, ResponseWriter) error
+
+func (f funcHandler) Handle(ctx context.Context, req *Request, resw ResponseWriter) error {
+	return f(ctx, req, resw)
+}
+
+func TestChainInterceptorsOrder(t *testing.T) {
+	var order []string
+	chain := ChainInterceptors(
+		orderInterceptor{name: "outer", order: &order},
+		orderInterceptor{name: "inner", order: &order},
+	)
+
+	h := funcHandler(func(context.Context, *Request, ResponseWriter) error {
+		order = append(order, "handler")
+		return nil
+	})
+
+	err := chain.Handle(context.Background(), &Request{}, fakeResponseWriter{}, h)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"before:outer", "before:inner", "handler", "after:inner", "after:outer"}, order)
+}
+
+func TestChainInterceptorsRecoversPanic(t *testing.T) {
+	chain := ChainInterceptors(orderInterceptor{name: "outer", order: &[]string{}})
+
+	var stackCtx context.Context
+	h := funcHandler(func(ctx context.Context, _ *Request, _ ResponseWriter) error {
+		stackCtx = ctx
+		panic("boom")
+	})
+
+	err := chain.Handle(context.Background(), &Request{}, fakeResponseWriter{}, h)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "boom")
+
+	stack, ok := StackTrace(stackCtx)
+	assert.True(t, ok)
+	assert.NotEmpty(t, stack)
+}
+
+func TestChainInterceptorsPropagatesHandlerError(t *testing.T) {
+	chain := ChainInterceptors(orderInterceptor{name: "outer", order: &[]string{}})
+
+	wantErr := errors.New("handler failed")
+	h := funcHandler(func(context.Context, *Request, ResponseWriter) error {
+		return wantErr
+	})
+
+	err := chain.Handle(context.Background(), &Request{}, fakeResponseWriter{}, h)
+	assert.Equal(t, wantErr, err)
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package transport
+
+// Headers is an immutable, case-sensitive mapping of header names to
+// values carried alongside a Request or Response. Transports that need
+// case-insensitive or namespaced semantics (HTTP, TChannel, ...) build
+// that behavior on top rather than Headers folding or prefixing keys
+// itself. The zero value is an empty Headers, ready to use.
+type Headers struct {
+	items map[string]string
+}
+
+// NewHeaders builds an empty Headers.
+func NewHeaders() Headers {
+	return Headers{}
+}
+
+// With returns a copy of h with key set to value.
+func (h Headers) With(key, value string) Headers {
+	items := make(map[string]string, len(h.items)+1)
+	for k, v := range h.items {
+		items[k] = v
+	}
+	items[key] = value
+	return Headers{items: items}
+}
+
+// Get returns the value of key and whether it was present.
+func (h Headers) Get(key string) (string, bool) {
+	v, ok := h.items[key]
+	return v, ok
+}
+
+// Len returns the number of headers.
+func (h Headers) Len() int {
+	return len(h.items)
+}
+
+// Items returns the headers as a map. Callers must not mutate the
+// returned map.
+func (h Headers) Items() map[string]string {
+	if h.items == nil {
+		return map[string]string{}
+	}
+	return h.items
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package transport
+
+import "io"
+
+// Encoding identifies the serialization format of a Request or Response
+// body, e.g. "json", "proto", "raw". Transports and encodings use it to
+// agree on how Body should be interpreted without either depending on
+// the other.
+type Encoding string
+
+// Request is a single RPC call, inbound to a Handler or outbound through
+// an Outbound.
+type Request struct {
+	// Caller is the name of the service making the request.
+	Caller string
+
+	// Service is the name of the service being called.
+	Service string
+
+	// Encoding is the encoding of the request body.
+	Encoding Encoding
+
+	// Procedure is the name of the procedure being called.
+	Procedure string
+
+	// Headers are the application headers to be sent to the remote
+	// service.
+	Headers Headers
+
+	// ShardKey is an opaque string used by some peer choosers to pick
+	// the peer to send the request to.
+	ShardKey string
+
+	// RoutingKey is procedure-independent routing metadata used by
+	// routing proxies to decide which backend cluster a request belongs
+	// to.
+	RoutingKey string
+
+	// RoutingDelegate is an identifier used by routing proxies to further
+	// refine the route a request takes.
+	RoutingDelegate string
+
+	// Body is the request body.
+	Body io.Reader
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package yarpctracing
+
+import (
+	"strings"
+
+	"golang.org/x/net/context"
+
+	"go.opentelemetry.io/otel/propagation"
+
+	"github.com/yarpc/yarpc-go/transport"
+)
+
+// Propagator extracts and injects a span context (and baggage) on
+// transport.Headers. Transports disagree on what a "header" is allowed to
+// look like (HTTP header names are case-insensitive and live in a
+// namespace of their own; TChannel application headers are a single flat,
+// case-sensitive string map shared with whatever headers the caller
+// sets), so each transport gets its own Propagator built on the same W3C
+// trace-context/baggage wire format.
+type Propagator interface {
+	// Extract reads a span context and baggage out of headers and returns
+	// a context carrying them.
+	Extract(ctx context.Context, headers transport.Headers) context.Context
+
+	// Inject writes ctx's span context and baggage into headers, returning
+	// the (possibly copied) result.
+	Inject(ctx context.Context, headers transport.Headers) transport.Headers
+}
+
+// headerCarrier adapts transport.Headers to otel's propagation.TextMapCarrier
+// so the standard TraceContext and Baggage propagators can read and write
+// it directly. keyPrefix namespaces the W3C keys within headers that are
+// shared with other, unrelated header values; canonicalize normalizes a
+// key before it is looked up or stored (e.g. lower-casing it for a
+// transport whose headers are case-insensitive).
+type headerCarrier struct {
+	headers      transport.Headers
+	keyPrefix    string
+	canonicalize func(string) string
+}
+
+func (c headerCarrier) canonicalKey(key string) string {
+	key = c.keyPrefix + key
+	if c.canonicalize != nil {
+		key = c.canonicalize(key)
+	}
+	return key
+}
+
+func (c headerCarrier) Get(key string) string {
+	v, _ := c.headers.Get(c.canonicalKey(key))
+	return v
+}
+
+func (c *headerCarrier) Set(key, value string) {
+	c.headers = c.headers.With(c.canonicalKey(key), value)
+}
+
+func (c headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(c.headers.Items()))
+	for k := range c.headers.Items() {
+		if c.keyPrefix != "" && !strings.HasPrefix(k, c.keyPrefix) {
+			continue
+		}
+		keys = append(keys, strings.TrimPrefix(k, c.keyPrefix))
+	}
+	return keys
+}
+
+// compositePropagator extracts/injects both trace context and baggage
+// using otel's TextMapPropagator, adapting the given header prefix and
+// canonicalization rules to transport.Headers.
+type compositePropagator struct {
+	inner        propagation.TextMapPropagator
+	keyPrefix    string
+	canonicalize func(string) string
+}
+
+func newCompositePropagator(keyPrefix string, canonicalize func(string) string) compositePropagator {
+	return compositePropagator{
+		inner: propagation.NewCompositeTextMapPropagator(
+			propagation.TraceContext{},
+			propagation.Baggage{},
+		),
+		keyPrefix:    keyPrefix,
+		canonicalize: canonicalize,
+	}
+}
+
+func (p compositePropagator) carrier(headers transport.Headers) headerCarrier {
+	return headerCarrier{headers: headers, keyPrefix: p.keyPrefix, canonicalize: p.canonicalize}
+}
+
+func (p compositePropagator) Extract(ctx context.Context, headers transport.Headers) context.Context {
+	carrier := p.carrier(headers)
+	return p.inner.Extract(ctx, &carrier)
+}
+
+func (p compositePropagator) Inject(ctx context.Context, headers transport.Headers) transport.Headers {
+	carrier := p.carrier(headers)
+	p.inner.Inject(ctx, &carrier)
+	return carrier.headers
+}
+
+// tchannelHeaderPrefix namespaces tracing metadata within TChannel
+// application headers. Unlike HTTP, which has a header namespace
+// dedicated to the transport, TChannel application headers are a single
+// flat string map shared with whatever headers the caller sets, so the
+// W3C keys are prefixed to avoid colliding with them.
+const tchannelHeaderPrefix = "$tracing$"
+
+// HTTPPropagator propagates trace context and baggage through HTTP
+// headers (traceparent, tracestate, baggage), as defined by the W3C Trace
+// Context and Baggage specifications. Keys are lower-cased before being
+// read or written, matching HTTP's case-insensitive header semantics.
+var HTTPPropagator Propagator = newCompositePropagator("", strings.ToLower)
+
+// TChannelPropagator propagates trace context and baggage through
+// TChannel application headers, using the same W3C wire format as
+// HTTPPropagator but with its keys namespaced under tchannelHeaderPrefix,
+// since TChannel application headers are an unrestricted, case-sensitive
+// string map shared with the caller's own headers.
+var TChannelPropagator Propagator = newCompositePropagator(tchannelHeaderPrefix, nil)
@@ -0,0 +1,54 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package yarpctracing
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPPropagatorLowercasesKeys(t *testing.T) {
+	carrier := headerCarrier{canonicalize: strings.ToLower}
+	carrier.Set("Traceparent", "00-...")
+
+	found := false
+	for k := range carrier.headers.Items() {
+		if k == "traceparent" {
+			found = true
+		}
+		assert.Equal(t, strings.ToLower(k), k)
+	}
+	assert.True(t, found)
+}
+
+func TestTChannelPropagatorNamespacesKeys(t *testing.T) {
+	carrier := headerCarrier{keyPrefix: tchannelHeaderPrefix}
+	carrier.Set("traceparent", "00-...")
+
+	for k := range carrier.headers.Items() {
+		assert.True(t, strings.HasPrefix(k, tchannelHeaderPrefix))
+	}
+
+	keys := carrier.Keys()
+	assert.Equal(t, []string{"traceparent"}, keys)
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package yarpctracing provides OpenTelemetry tracing for YARPC transports.
+//
+// An Interceptor extracts an incoming span context from the request and
+// starts a new server span around the handler; a Filter does the mirror
+// image on the outbound path, starting a client span and injecting it into
+// the outgoing request before calling the next Outbound. Both are built
+// from the same TracerProvider and Propagator so that a single
+// configuration point produces a consistent trace across every hop.
+//
+// Interceptor and Filter are meant to be registered once, wherever the rest
+// of the built-in middleware (logging, metrics, auth) is wired up, e.g.
+//
+//	tracer := yarpctracing.NewInterceptor(tracerProvider, yarpctracing.HTTPPropagator)
+//	filter := yarpctracing.NewFilter(tracerProvider, yarpctracing.HTTPPropagator)
+package yarpctracing
+
+import (
+	"golang.org/x/net/context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/yarpc/yarpc-go/transport"
+)
+
+// TracerProvider is the subset of the OpenTelemetry TracerProvider that
+// yarpctracing depends on. It is satisfied by *sdktrace.TracerProvider and
+// by otel's global provider, and lets callers inject a fake in tests.
+type TracerProvider interface {
+	Tracer(name string, opts ...trace.TracerOption) trace.Tracer
+}
+
+const tracerName = "github.com/yarpc/yarpc-go/transport/yarpctracing"
+
+// NewInterceptor builds a transport.Interceptor that extracts a span
+// context from the inbound request using prop, starts a server span named
+// after the procedure, records peer/service/encoding attributes on it, and
+// ends the span with the handler's error (if any) when the handler
+// returns.
+func NewInterceptor(tp TracerProvider, prop Propagator) transport.Interceptor {
+	return &interceptor{tracer: tp.Tracer(tracerName), prop: prop}
+}
+
+type interceptor struct {
+	tracer trace.Tracer
+	prop   Propagator
+}
+
+func (i *interceptor) Handle(ctx context.Context, req *transport.Request, resw transport.ResponseWriter, h transport.Handler) error {
+	ctx = i.prop.Extract(ctx, req.Headers)
+
+	ctx, span := i.tracer.Start(ctx, req.Procedure, trace.WithSpanKind(trace.SpanKindServer))
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("rpc.system", "yarpc"),
+		attribute.String("rpc.service", req.Service),
+		attribute.String("rpc.method", req.Procedure),
+		attribute.String("peer.service", req.Caller),
+		attribute.String("rpc.yarpc.encoding", string(req.Encoding)),
+	)
+
+	err := h.Handle(ctx, req, resw)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+// NewFilter builds a transport.Filter that starts a client span named
+// after the procedure, injects it into the outbound request's headers
+// using prop, and records the span's status from the call's error.
+func NewFilter(tp TracerProvider, prop Propagator) transport.Filter {
+	return &filter{tracer: tp.Tracer(tracerName), prop: prop}
+}
+
+type filter struct {
+	tracer trace.Tracer
+	prop   Propagator
+}
+
+func (f *filter) Call(ctx context.Context, req *transport.Request, out transport.Outbound) (*transport.Response, error) {
+	ctx, span := f.tracer.Start(ctx, req.Procedure, trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("rpc.system", "yarpc"),
+		attribute.String("rpc.service", req.Service),
+		attribute.String("rpc.method", req.Procedure),
+		attribute.String("rpc.yarpc.encoding", string(req.Encoding)),
+	)
+
+	req.Headers = f.prop.Inject(ctx, req.Headers)
+
+	res, err := out.Call(ctx, req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return res, err
+}
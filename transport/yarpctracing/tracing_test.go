@@ -0,0 +1,103 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package yarpctracing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/net/context"
+
+	"github.com/yarpc/yarpc-go/transport"
+)
+
+// fakeOutbound hands the request straight to an inbound interceptor,
+// simulating the wire hop between a client Filter and a server
+// Interceptor: headers written by Inject are exactly what Extract sees.
+type fakeOutbound struct {
+	interceptor transport.Interceptor
+	handler     transport.Handler
+}
+
+func (o fakeOutbound) Call(ctx context.Context, req *transport.Request) (*transport.Response, error) {
+	resw := new(fakeResponseWriter)
+	err := o.interceptor.Handle(ctx, req, resw, o.handler)
+	return &transport.Response{}, err
+}
+
+type fakeResponseWriter struct {
+	appError bool
+}
+
+func (w *fakeResponseWriter) Write(p []byte) (int, error) { return len(p), nil }
+func (w *fakeResponseWriter) AddHeaders(transport.Headers) {}
+func (w *fakeResponseWriter) SetApplicationError()          { w.appError = true }
+
+// handlerFunc adapts a function into a transport.Handler for tests.
+type handlerFunc func(context.Context, *transport.Request, transport.ResponseWriter) error
+
+func (f handlerFunc) Handle(ctx context.Context, req *transport.Request, resw transport.ResponseWriter) error {
+	return f(ctx, req, resw)
+}
+
+func TestTwoHopPropagation(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	var sawTraceID, sawSpanID string
+	handler := handlerFunc(func(ctx context.Context, req *transport.Request, resw transport.ResponseWriter) error {
+		span := trace.SpanFromContext(ctx)
+		sawTraceID = span.SpanContext().TraceID().String()
+		sawSpanID = span.SpanContext().SpanID().String()
+		return nil
+	})
+
+	server := NewInterceptor(tp, HTTPPropagator)
+	client := NewFilter(tp, HTTPPropagator)
+	out := fakeOutbound{interceptor: server, handler: handler}
+
+	req := &transport.Request{Service: "svc", Caller: "caller", Procedure: "Echo"}
+	_, err := client.Call(context.Background(), req, out)
+	require.NoError(t, err)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+
+	var clientSpan, serverSpan tracetest.SpanStub
+	for _, span := range spans {
+		switch span.SpanKind {
+		case trace.SpanKindClient:
+			clientSpan = span
+		case trace.SpanKindServer:
+			serverSpan = span
+		}
+	}
+
+	assert.Equal(t, "Echo", clientSpan.Name)
+	assert.Equal(t, "Echo", serverSpan.Name)
+	assert.Equal(t, clientSpan.SpanContext.TraceID().String(), sawTraceID)
+	assert.NotEqual(t, clientSpan.SpanContext.SpanID().String(), sawSpanID)
+	assert.Equal(t, clientSpan.SpanContext.SpanID().String(), serverSpan.Parent.SpanID().String())
+}
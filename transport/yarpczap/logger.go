@@ -0,0 +1,106 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package yarpczap provides a zap-backed structured logging Interceptor
+// for the interceptor pipeline described in transport.ChainInterceptors.
+//
+// Wire it in alongside the other built-in interceptors (tracing, metrics)
+// using whatever Logger is otherwise shared across them, e.g.
+//
+//	transport.ChainInterceptors(
+//	    yarpctracing.NewInterceptor(tracerProvider, yarpctracing.HTTPPropagator),
+//	    yarpczap.NewInterceptor(logger),
+//	)
+package yarpczap
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"golang.org/x/net/context"
+
+	"github.com/yarpc/yarpc-go/transport"
+)
+
+// Option configures the Interceptor returned by NewInterceptor.
+type Option func(*options)
+
+type options struct {
+	sampler Sampler
+}
+
+// WithSampler overrides the Sampler used to decide whether a successful
+// request is logged. Defaults to AlwaysSample.
+func WithSampler(s Sampler) Option {
+	return func(o *options) { o.sampler = s }
+}
+
+// NewInterceptor builds a transport.Interceptor that logs one structured
+// entry per inbound RPC to logger: caller, service, procedure, encoding,
+// shard key, routing key, duration, response size, and error (if any).
+// Failures and application errors are always logged at error level;
+// successful requests are logged at info level, subject to opts' Sampler.
+func NewInterceptor(logger *zap.Logger, opts ...Option) transport.Interceptor {
+	o := options{sampler: AlwaysSample()}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &interceptor{logger: logger, sampler: o.sampler}
+}
+
+type interceptor struct {
+	logger  *zap.Logger
+	sampler Sampler
+}
+
+func (i *interceptor) Handle(ctx context.Context, req *transport.Request, resw transport.ResponseWriter, h transport.Handler) error {
+	start := time.Now()
+	counting := newCountingResponseWriter(resw)
+
+	err := h.Handle(ctx, req, counting)
+	duration := time.Since(start)
+
+	if err == nil && !counting.appError && !i.sampler.Sample() {
+		return err
+	}
+
+	fields := []zapcore.Field{
+		zap.String("caller", req.Caller),
+		zap.String("service", req.Service),
+		zap.String("procedure", req.Procedure),
+		zap.String("encoding", string(req.Encoding)),
+		zap.String("shardKey", req.ShardKey),
+		zap.String("routingKey", req.RoutingKey),
+		zap.Duration("duration", duration),
+		zap.Int("responseSize", counting.bytesWritten),
+	}
+
+	switch {
+	case err != nil:
+		i.logger.Error("rpc failed", append(fields, zap.Error(err))...)
+	case counting.appError:
+		i.logger.Error("rpc returned an application error", fields...)
+	default:
+		i.logger.Info("rpc succeeded", fields...)
+	}
+
+	return err
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package yarpczap
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"golang.org/x/net/context"
+
+	"github.com/yarpc/yarpc-go/transport"
+)
+
+type fakeResponseWriter struct {
+	appError bool
+}
+
+func (w *fakeResponseWriter) Write(p []byte) (int, error) { return len(p), nil }
+func (w *fakeResponseWriter) AddHeaders(transport.Headers) {}
+func (w *fakeResponseWriter) SetApplicationError()          { w.appError = true }
+
+func newObservedInterceptor(opts ...Option) (transport.Interceptor, *observer.ObservedLogs) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	return NewInterceptor(zap.New(core), opts...), logs
+}
+
+func TestInterceptorLogsSuccess(t *testing.T) {
+	i, logs := newObservedInterceptor()
+
+	handler := handlerFunc(func(ctx context.Context, req *transport.Request, resw transport.ResponseWriter) error {
+		_, _ = resw.Write([]byte("hello"))
+		return nil
+	})
+
+	req := &transport.Request{Service: "svc", Procedure: "Echo"}
+	err := i.Handle(context.Background(), req, &fakeResponseWriter{}, handler)
+	require.NoError(t, err)
+
+	require.Equal(t, 1, logs.Len())
+	entry := logs.All()[0]
+	assert.Equal(t, zapcore.InfoLevel, entry.Level)
+	assert.Equal(t, "Echo", entry.ContextMap()["procedure"])
+	assert.Equal(t, int64(5), entry.ContextMap()["responseSize"])
+}
+
+func TestInterceptorLogsError(t *testing.T) {
+	i, logs := newObservedInterceptor()
+
+	wantErr := errors.New("boom")
+	handler := handlerFunc(func(context.Context, *transport.Request, transport.ResponseWriter) error {
+		return wantErr
+	})
+
+	err := i.Handle(context.Background(), &transport.Request{}, &fakeResponseWriter{}, handler)
+	assert.Equal(t, wantErr, err)
+
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, zapcore.ErrorLevel, logs.All()[0].Level)
+}
+
+func TestInterceptorSamplesSuccess(t *testing.T) {
+	i, logs := newObservedInterceptor(WithSampler(zeroSampler{}))
+
+	handler := handlerFunc(func(context.Context, *transport.Request, transport.ResponseWriter) error {
+		return nil
+	})
+
+	err := i.Handle(context.Background(), &transport.Request{}, &fakeResponseWriter{}, handler)
+	require.NoError(t, err)
+	assert.Equal(t, 0, logs.Len())
+}
+
+type handlerFunc func(context.Context, *transport.Request, transport.ResponseWriter) error
+
+func (f handlerFunc) Handle(ctx context.Context, req *transport.Request, resw transport.ResponseWriter) error {
+	return f(ctx, req, resw)
+}
+
+type zeroSampler struct{}
+
+func (zeroSampler) Sample() bool { return false }
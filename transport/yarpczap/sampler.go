@@ -0,0 +1,66 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package yarpczap
+
+import "math/rand"
+
+// Sampler decides whether a successful (non-error, non-application-error)
+// request should be logged. Failed requests and requests that set the
+// application error bit are always logged, regardless of what Sampler
+// returns, so that errors are never lost to sampling.
+type Sampler interface {
+	Sample() bool
+}
+
+// AlwaysSample returns a Sampler that logs every successful request. It
+// is the default used by NewInterceptor.
+func AlwaysSample() Sampler { return alwaysSample{} }
+
+type alwaysSample struct{}
+
+func (alwaysSample) Sample() bool { return true }
+
+// RateSampler logs successful requests at roughly the given rate, letting
+// high-volume, low-value success logs be thinned out while still logging
+// every error at full rate.
+type RateSampler struct {
+	// Rate is the fraction of successful requests to log, from 0 (none)
+	// to 1 (all).
+	Rate float64
+}
+
+// NewRateSampler builds a RateSampler that logs successful requests at
+// roughly the given rate.
+func NewRateSampler(rate float64) *RateSampler {
+	return &RateSampler{Rate: rate}
+}
+
+// Sample for RateSampler.
+func (s *RateSampler) Sample() bool {
+	switch {
+	case s.Rate >= 1:
+		return true
+	case s.Rate <= 0:
+		return false
+	default:
+		return rand.Float64() < s.Rate
+	}
+}
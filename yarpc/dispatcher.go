@@ -0,0 +1,95 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package yarpc provides the Dispatcher through which a service registers
+// procedure Handlers and configures the cross-cutting Interceptors
+// (tracing, logging, auth, rate-limiting, panic recovery, ...) applied to
+// every one of them.
+package yarpc
+
+import (
+	"go.uber.org/zap"
+
+	"github.com/yarpc/yarpc-go/transport"
+)
+
+// Config configures a Dispatcher.
+type Config struct {
+	// Name is the name of the service being dispatched for.
+	Name string
+
+	// Interceptor is applied to every procedure registered with the
+	// Dispatcher. Build one from multiple interceptors with
+	// transport.ChainInterceptors, or add more later with
+	// Dispatcher.Intercept.
+	Interceptor transport.Interceptor
+
+	// Logger is the *zap.Logger for interceptors that take one, such as
+	// yarpczap's. Retrieve it with Dispatcher.Logger when constructing
+	// those interceptors; the Dispatcher does not pass it to them itself,
+	// and yarpctracing's Interceptor and Filter take no logger. Defaults
+	// to zap.NewNop() if unset.
+	Logger *zap.Logger
+}
+
+// Dispatcher registers Handlers for procedures and applies the
+// Interceptor configured on it to every one of them.
+type Dispatcher struct {
+	cfg Config
+}
+
+// NewDispatcher builds a Dispatcher from cfg.
+func NewDispatcher(cfg Config) *Dispatcher {
+	return &Dispatcher{cfg: cfg}
+}
+
+// Logger returns the *zap.Logger configured via Config.Logger, or a no-op
+// logger if none was set, so built-in interceptors can always log through
+// it without a nil check, e.g.
+//
+//	d := yarpc.NewDispatcher(yarpc.Config{Name: "svc", Logger: logger})
+//	d.Intercept(yarpczap.NewInterceptor(d.Logger()))
+func (d *Dispatcher) Logger() *zap.Logger {
+	if d.cfg.Logger == nil {
+		return zap.NewNop()
+	}
+	return d.cfg.Logger
+}
+
+// Intercept adds interceptors to the Dispatcher's chain, outermost first,
+// alongside whatever was already configured via Config.Interceptor.
+// Intercept is meant to be called once, during setup, before any
+// procedures are registered: every procedure registered with Register
+// afterwards is wrapped with the resulting chain, so a single
+// registration point applies it globally instead of per procedure.
+func (d *Dispatcher) Intercept(interceptors ...transport.Interceptor) {
+	chained := make([]transport.Interceptor, 0, len(interceptors)+1)
+	if d.cfg.Interceptor != nil {
+		chained = append(chained, d.cfg.Interceptor)
+	}
+	chained = append(chained, interceptors...)
+	d.cfg.Interceptor = transport.ChainInterceptors(chained...)
+}
+
+// Register wraps h with the Dispatcher's configured Interceptor. The
+// result is what should be handed to a transport's Router for procedure.
+func (d *Dispatcher) Register(procedure string, h transport.Handler) transport.Handler {
+	return transport.ApplyInterceptor(h, d.cfg.Interceptor)
+}
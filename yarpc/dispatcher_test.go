@@ -0,0 +1,76 @@
+// Copyright (c) 2016 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package yarpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+
+	"github.com/yarpc/yarpc-go/transport"
+)
+
+type fakeResponseWriter struct{}
+
+func (fakeResponseWriter) Write(p []byte) (int, error) { return len(p), nil }
+func (fakeResponseWriter) AddHeaders(transport.Headers) {}
+func (fakeResponseWriter) SetApplicationError()         {}
+
+type handlerFunc func(context.Context, *transport.Request, transport.ResponseWriter) error
+
+func (f handlerFunc) Handle(ctx context.Context, req *transport.Request, resw transport.ResponseWriter) error {
+	return f(ctx, req, resw)
+}
+
+func recordingInterceptor(name string, order *[]string) transport.Interceptor {
+	return transport.InterceptorFunc(func(ctx context.Context, req *transport.Request, resw transport.ResponseWriter, h transport.Handler) error {
+		*order = append(*order, name)
+		return h.Handle(ctx, req, resw)
+	})
+}
+
+func TestDispatcherInterceptAppliesGlobally(t *testing.T) {
+	var order []string
+	d := NewDispatcher(Config{Name: "svc", Interceptor: recordingInterceptor("configured", &order)})
+	d.Intercept(recordingInterceptor("outer", &order), recordingInterceptor("inner", &order))
+
+	noop := handlerFunc(func(context.Context, *transport.Request, transport.ResponseWriter) error {
+		return nil
+	})
+	h := d.Register("Echo", noop)
+	err := h.Handle(context.Background(), &transport.Request{Procedure: "Echo"}, fakeResponseWriter{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"configured", "outer", "inner"}, order)
+}
+
+func TestDispatcherLoggerDefaultsToNop(t *testing.T) {
+	d := NewDispatcher(Config{Name: "svc"})
+	assert.NotNil(t, d.Logger())
+}
+
+func TestDispatcherLoggerReturnsConfigured(t *testing.T) {
+	logger := zap.NewExample()
+	d := NewDispatcher(Config{Name: "svc", Logger: logger})
+	assert.Same(t, logger, d.Logger())
+}